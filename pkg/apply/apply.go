@@ -0,0 +1,172 @@
+// Copyright 2016 Mirantis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package apply implements a small subset of kubectl's cli-runtime apply
+// engine: a three-way strategic-merge patch between the last applied
+// configuration (stored in an annotation), the object as currently defined
+// in the dependency graph, and the object as it actually exists on the
+// server. It lets Create() calls reconcile spec drift instead of silently
+// no-op'ing once a resource already exists.
+package apply
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/client-go/pkg/api"
+	"k8s.io/client-go/pkg/api/errors"
+	"k8s.io/client-go/pkg/api/meta"
+	"k8s.io/client-go/pkg/runtime"
+	"k8s.io/client-go/pkg/types"
+	"k8s.io/client-go/pkg/util/strategicpatch"
+)
+
+// LastAppliedAnnotation stores the JSON-serialized configuration that was
+// last successfully applied through this package, the same role
+// kubectl.kubernetes.io/last-applied-configuration plays for kubectl apply.
+const LastAppliedAnnotation = "appcontroller.k8s/last-applied"
+
+// maxConflictRetries bounds how many times Apply re-fetches the live object
+// and recomputes its patch after a 409 Conflict before giving up.
+const maxConflictRetries = 3
+
+// Getter fetches the live version of the object being applied.
+type Getter func() (runtime.Object, error)
+
+// Patcher applies a patch of the given type to the live object and returns
+// the result as the server persisted it.
+type Patcher func(patchType types.PatchType, patch []byte) (runtime.Object, error)
+
+// Applier computes and applies a three-way strategic-merge patch so that a
+// changed resource definition in the graph converges the live object
+// instead of being ignored once the object already exists.
+//
+// This is a plain struct, not an interface with multiple backends: the
+// vendored strategicpatch in this tree derives its merge schema from the
+// target Go struct's json/patchStrategy tags by reflection, via
+// CreateThreeWayMergePatch's dataStruct argument - there is no
+// discovery-backed OpenAPI schema lookup. That's deliberately out of scope
+// here, not just deferred: every resources.Kind in this tree (Service, Job,
+// ReplicaSet, ...) is a registered, vendored Go type, and nothing in the
+// tree constructs a CRD/unstructured.Unstructured resource to apply in the
+// first place, so there is no caller this package would need a JSON-merge
+// fallback for yet. Adding one now would mean building the dynamic client
+// and discovery-backed schema fetch a CRD path needs without anything to
+// exercise it against.
+type Applier struct{}
+
+// New returns an Applier.
+func New() *Applier {
+	return &Applier{}
+}
+
+// Apply fetches the live object via get, computes a three-way strategic
+// merge patch between its last-applied-configuration annotation, obj as
+// currently defined, and the live object, and sends it through patch. When
+// dryRun is true the patch is computed and returned but never sent, which is
+// what backs a future `plan` subcommand.
+func (a *Applier) Apply(obj runtime.Object, get Getter, patch Patcher, dryRun bool) (result runtime.Object, patchBytes []byte, err error) {
+	modified, err := withLastApplied(obj)
+	if err != nil {
+		return nil, nil, fmt.Errorf("recording last-applied-configuration: %v", err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		live, err := get()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		original, err := lastApplied(live)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		current, err := json.Marshal(live)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		patchBytes, err = strategicpatch.CreateThreeWayMergePatch(original, modified, current, obj, true)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if dryRun {
+			return nil, patchBytes, nil
+		}
+
+		result, err = patch(types.StrategicMergePatchType, patchBytes)
+		if err == nil {
+			return result, patchBytes, nil
+		}
+		if !errors.IsConflict(err) || attempt >= maxConflictRetries {
+			return nil, patchBytes, err
+		}
+	}
+}
+
+// withLastApplied returns the JSON serialization of obj annotated with its
+// own bare JSON (i.e. without the annotation itself) under
+// LastAppliedAnnotation. The result becomes both the PATCH's "modified"
+// input and the annotation value future Apply calls diff against.
+//
+// It works against a deep copy of obj rather than mutating it in place:
+// obj is the caller's live dependency-graph node (e.g. Service.Service),
+// and writing the last-applied annotation onto it directly would leave
+// that in-memory node carrying an annotation it never declared, visible to
+// every other resource that reads it for the rest of the run.
+func withLastApplied(obj runtime.Object) ([]byte, error) {
+	copied, err := api.Scheme.Copy(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	accessor, err := meta.Accessor(copied)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := accessor.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	delete(annotations, LastAppliedAnnotation)
+	accessor.SetAnnotations(annotations)
+
+	bare, err := json.Marshal(copied)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations[LastAppliedAnnotation] = string(bare)
+	accessor.SetAnnotations(annotations)
+
+	return json.Marshal(copied)
+}
+
+// lastApplied returns the LastAppliedAnnotation value stored on live, or an
+// empty JSON object if live has never been applied through this package
+// (e.g. it was created out of band). An empty original degrades the
+// three-way merge to a two-way merge against the current object.
+func lastApplied(live runtime.Object) ([]byte, error) {
+	accessor, err := meta.Accessor(live)
+	if err != nil {
+		return nil, err
+	}
+	if original, ok := accessor.GetAnnotations()[LastAppliedAnnotation]; ok {
+		return []byte(original), nil
+	}
+	return []byte("{}"), nil
+}
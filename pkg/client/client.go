@@ -0,0 +1,87 @@
+// Copyright 2016 Mirantis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package client wraps the various typed Kubernetes clients AppController
+// talks to behind a single client.Interface, and declares the
+// ResourceDefinition discriminator that resource template YAML decodes
+// into: exactly one of its fields is expected to be set per definition,
+// selecting which resources.Kind handles it.
+package client
+
+import (
+	"k8s.io/client-go/discovery"
+	appsv1beta1 "k8s.io/client-go/kubernetes/typed/apps/v1beta1"
+	batchv1 "k8s.io/client-go/kubernetes/typed/batch/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	extensionsv1beta1 "k8s.io/client-go/kubernetes/typed/extensions/v1beta1"
+	"k8s.io/client-go/pkg/api"
+	"k8s.io/client-go/pkg/api/v1"
+	appsapi "k8s.io/client-go/pkg/apis/apps/v1beta1"
+	batchapi "k8s.io/client-go/pkg/apis/batch/v1"
+	extensionsapi "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+	"k8s.io/client-go/pkg/runtime/schema"
+)
+
+// PetSetInterface is the pre-1.5 predecessor of StatefulSetInterface, kept
+// around so clusters that haven't upgraded yet still get status feedback.
+type PetSetInterface interface {
+	List(options api.ListOptions) (*appsapi.PetSetList, error)
+}
+
+// Interface is the set of typed clients AppController resources are built
+// against, plus cluster-shape introspection (IsEnabled, Discovery) used to
+// pick between API generations that provide the same functionality under
+// different GroupVersions (StatefulSet vs. PetSet, and so on).
+type Interface interface {
+	Pods() corev1.PodInterface
+	Services() corev1.ServiceInterface
+	Endpoints() corev1.EndpointsInterface
+	Secrets() corev1.SecretInterface
+	Namespaces() corev1.NamespaceInterface
+	Jobs() batchv1.JobInterface
+	ReplicaSets() extensionsv1beta1.ReplicaSetInterface
+	Ingresses() extensionsv1beta1.IngressInterface
+	Deployments() extensionsv1beta1.DeploymentInterface
+	StatefulSets() appsv1beta1.StatefulSetInterface
+	PetSets() PetSetInterface
+
+	// IsEnabled reports whether gv is served by the cluster. Prefer
+	// Discovery() for new call sites: IsEnabled re-queries the API server
+	// on every call, while Discovery() caches for the life of the process.
+	IsEnabled(gv schema.GroupVersion) bool
+
+	// Discovery returns a discovery client whose ServerGroups response is
+	// cached for the life of the process (see discovery.NewMemCacheClient),
+	// so resources that need to pick between API generations don't each
+	// re-query the API server (see resources.groupVersionAvailable). Nothing
+	// currently calls Invalidate() on it, so a GroupVersion that becomes
+	// available after this client was built (e.g. a CRD installed mid-run)
+	// won't be picked up until the process restarts.
+	Discovery() discovery.CachedDiscoveryInterface
+}
+
+// ResourceDefinition is what a template's YAML/JSON resource entry decodes
+// into. Exactly one field is expected to be non-nil per definition; it
+// selects which resources.Kind's NameMatches/New/NewExisting handle it.
+type ResourceDefinition struct {
+	Name        string                    `json:"name,omitempty"`
+	Meta        map[string]interface{}    `json:"meta,omitempty"`
+	Service     *v1.Service               `json:"service,omitempty"`
+	Ingress     *extensionsapi.Ingress    `json:"ingress,omitempty"`
+	Namespace   *v1.Namespace             `json:"namespace,omitempty"`
+	Job         *batchapi.Job             `json:"job,omitempty"`
+	ReplicaSet  *extensionsapi.ReplicaSet `json:"replicaSet,omitempty"`
+	Deployment  *extensionsapi.Deployment `json:"deployment,omitempty"`
+	StatefulSet *appsapi.StatefulSet      `json:"statefulSet,omitempty"`
+}
@@ -0,0 +1,50 @@
+// Copyright 2016 Mirantis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package interfaces declares the contracts resources.Kind implementations
+// satisfy and the scheduler/reporter drive against.
+package interfaces
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/Mirantis/k8s-AppController/pkg/client"
+)
+
+// BaseResource is the behavior every graph node implements: it can be
+// created, deleted, report its own readiness, and be matched against /
+// constructed from a ResourceDefinition.
+type BaseResource interface {
+	Key() string
+	Create() error
+	Delete() error
+	Status(meta map[string]string) (string, error)
+	NameMatches(def client.ResourceDefinition, name string) bool
+	New(def client.ResourceDefinition, c client.Interface) Resource
+	NewExisting(name string, c client.Interface) Resource
+}
+
+// Resource is a BaseResource wrapped with reporting, which is what the
+// scheduler actually schedules and polls.
+type Resource interface {
+	BaseResource
+}
+
+// WaitableResource is implemented by resources whose readiness depends on
+// more than their own Status() - e.g. a Service that needs to wait for
+// kube-proxy to program Endpoints. Wait blocks until the resource converges,
+// ctx is cancelled, or an implementation-defined deadline elapses.
+type WaitableResource interface {
+	Wait(ctx context.Context, meta map[string]string) (string, error)
+}
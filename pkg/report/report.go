@@ -0,0 +1,68 @@
+// Copyright 2016 Mirantis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package report wraps resources.Kind implementations so the scheduler
+// gets a uniform interfaces.Resource regardless of which BaseResource is
+// underneath.
+package report
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/Mirantis/k8s-AppController/pkg/client"
+	"github.com/Mirantis/k8s-AppController/pkg/interfaces"
+)
+
+// SimpleReporter wraps a BaseResource, forwarding every call to it.
+type SimpleReporter struct {
+	BaseResource interfaces.BaseResource
+}
+
+func (r SimpleReporter) Key() string {
+	return r.BaseResource.Key()
+}
+
+func (r SimpleReporter) Create() error {
+	return r.BaseResource.Create()
+}
+
+func (r SimpleReporter) Delete() error {
+	return r.BaseResource.Delete()
+}
+
+func (r SimpleReporter) Status(meta map[string]string) (string, error) {
+	return r.BaseResource.Status(meta)
+}
+
+func (r SimpleReporter) NameMatches(def client.ResourceDefinition, name string) bool {
+	return r.BaseResource.NameMatches(def, name)
+}
+
+func (r SimpleReporter) New(def client.ResourceDefinition, c client.Interface) interfaces.Resource {
+	return r.BaseResource.New(def, c)
+}
+
+func (r SimpleReporter) NewExisting(name string, c client.Interface) interfaces.Resource {
+	return r.BaseResource.NewExisting(name, c)
+}
+
+// Wait forwards to the wrapped BaseResource's Wait when it implements
+// interfaces.WaitableResource, and otherwise falls back to a single Status
+// check so callers can treat every SimpleReporter as waitable.
+func (r SimpleReporter) Wait(ctx context.Context, meta map[string]string) (string, error) {
+	if w, ok := r.BaseResource.(interfaces.WaitableResource); ok {
+		return w.Wait(ctx, meta)
+	}
+	return r.Status(meta)
+}
@@ -0,0 +1,34 @@
+// Copyright 2016 Mirantis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"k8s.io/client-go/pkg/runtime"
+
+	"github.com/Mirantis/k8s-AppController/pkg/apply"
+)
+
+// applyChanges reconciles drift between obj as currently defined in the
+// dependency graph and the live object returned by get, via patch. It is the
+// common Create()-time convergence path shared by every resource kind whose
+// spec can change in place (Service, Deployment, ReplicaSet, StatefulSet,
+// Job, ...), instead of each Create() silently ignoring drift once the
+// object already exists. meta["dryRun"] == "true" computes the patch without
+// sending it.
+func applyChanges(obj runtime.Object, get apply.Getter, patch apply.Patcher, meta map[string]interface{}) error {
+	dryRun, _ := meta["dryRun"].(string)
+	_, _, err := apply.New().Apply(obj, get, patch, dryRun == "true")
+	return err
+}
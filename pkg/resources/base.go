@@ -0,0 +1,71 @@
+// Copyright 2016 Mirantis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/Mirantis/k8s-AppController/pkg/interfaces"
+)
+
+// Base carries the per-node meta map every resources.Kind embeds, so
+// Create/Status/Delete implementations can read template-level options like
+// meta["timeout"] or meta["wait"].
+type Base struct {
+	Meta map[string]interface{}
+}
+
+// checkExistence returns nil when r's live counterpart already exists (so
+// Create() should converge it instead of creating), and a non-nil error
+// when it doesn't (so Create() should create it). Existence is judged from
+// r.Status(nil) alone: a Status error or an "error" status (e.g. a failed
+// Job) also yields a non-nil error, even when the object exists.
+func checkExistence(r interfaces.BaseResource) error {
+	status, err := r.Status(nil)
+	if err != nil || status == "error" {
+		return fmt.Errorf("resource %s was not found: %v", r.Key(), err)
+	}
+	return nil
+}
+
+// createExistingResource is Create() for ExistingX resources: they don't
+// own a spec to create from, so all Create() can do is confirm the resource
+// it references already exists in the cluster.
+func createExistingResource(r interfaces.BaseResource) error {
+	status, err := r.Status(nil)
+	if err != nil {
+		return err
+	}
+	if status == "error" {
+		return fmt.Errorf("existing resource %s was not found in the cluster", r.Key())
+	}
+	return nil
+}
+
+// resourceListReady aggregates the Status of every resource in the list:
+// "ready" only if all of them are, the first non-ready status otherwise, and
+// "error" (with the underlying error) if any Status call fails.
+func resourceListReady(resources []interfaces.BaseResource) (string, error) {
+	for _, r := range resources {
+		status, err := r.Status(nil)
+		if err != nil {
+			return "error", err
+		}
+		if status != "ready" {
+			return status, nil
+		}
+	}
+	return "ready", nil
+}
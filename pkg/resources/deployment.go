@@ -0,0 +1,130 @@
+// Copyright 2016 Mirantis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"log"
+
+	extensionsv1beta1client "k8s.io/client-go/kubernetes/typed/extensions/v1beta1"
+	extensionsv1beta1 "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+	"k8s.io/client-go/pkg/runtime"
+	"k8s.io/client-go/pkg/types"
+
+	"github.com/Mirantis/k8s-AppController/pkg/client"
+	"github.com/Mirantis/k8s-AppController/pkg/interfaces"
+	"github.com/Mirantis/k8s-AppController/pkg/report"
+)
+
+type Deployment struct {
+	Base
+	Deployment *extensionsv1beta1.Deployment
+	Client     extensionsv1beta1client.DeploymentInterface
+}
+
+func deploymentStatus(d *extensionsv1beta1.Deployment) (string, error) {
+	if d.Status.AvailableReplicas >= *d.Spec.Replicas {
+		return "ready", nil
+	}
+	return "not ready", nil
+}
+
+func deploymentKey(name string) string {
+	return "deployment/" + name
+}
+
+func (d Deployment) Key() string {
+	return deploymentKey(d.Deployment.Name)
+}
+
+func (d Deployment) Create() error {
+	if err := checkExistence(d); err != nil {
+		log.Println("Creating ", d.Key())
+		d.Deployment, err = d.Client.Create(d.Deployment)
+		return err
+	}
+	return applyChanges(
+		d.Deployment,
+		func() (runtime.Object, error) { return d.Client.Get(d.Deployment.Name) },
+		func(patchType types.PatchType, patch []byte) (runtime.Object, error) {
+			return d.Client.Patch(d.Deployment.Name, patchType, patch)
+		},
+		d.Meta,
+	)
+}
+
+// Delete deletes Deployment from the cluster
+func (d Deployment) Delete() error {
+	return d.Client.Delete(d.Deployment.Name, nil)
+}
+
+func (d Deployment) Status(meta map[string]string) (string, error) {
+	deployment, err := d.Client.Get(d.Deployment.Name)
+	if err != nil {
+		return "error", err
+	}
+	return deploymentStatus(deployment)
+}
+
+// NameMatches gets resource definition and a name and checks if
+// the Deployment part of resource definition has matching name.
+func (d Deployment) NameMatches(def client.ResourceDefinition, name string) bool {
+	return def.Deployment != nil && def.Deployment.Name == name
+}
+
+// New returns new Deployment based on resource definition
+func (d Deployment) New(def client.ResourceDefinition, c client.Interface) interfaces.Resource {
+	return NewDeployment(def.Deployment, c.Deployments(), def.Meta)
+}
+
+// NewExisting returns new ExistingDeployment based on resource definition
+func (d Deployment) NewExisting(name string, c client.Interface) interfaces.Resource {
+	return NewExistingDeployment(name, c.Deployments())
+}
+
+// NewDeployment is Deployment constructor
+func NewDeployment(deployment *extensionsv1beta1.Deployment, client extensionsv1beta1client.DeploymentInterface, meta map[string]interface{}) interfaces.Resource {
+	return report.SimpleReporter{BaseResource: Deployment{Base: Base{meta}, Deployment: deployment, Client: client}}
+}
+
+type ExistingDeployment struct {
+	Base
+	Name   string
+	Client extensionsv1beta1client.DeploymentInterface
+}
+
+func (d ExistingDeployment) Key() string {
+	return deploymentKey(d.Name)
+}
+
+func (d ExistingDeployment) Create() error {
+	return createExistingResource(d)
+}
+
+func (d ExistingDeployment) Status(meta map[string]string) (string, error) {
+	deployment, err := d.Client.Get(d.Name)
+	if err != nil {
+		return "error", err
+	}
+	return deploymentStatus(deployment)
+}
+
+// Delete deletes Deployment from the cluster
+func (d ExistingDeployment) Delete() error {
+	return d.Client.Delete(d.Name, nil)
+}
+
+func NewExistingDeployment(name string, client extensionsv1beta1client.DeploymentInterface) interfaces.Resource {
+	return report.SimpleReporter{BaseResource: ExistingDeployment{Name: name, Client: client}}
+}
@@ -0,0 +1,161 @@
+// Copyright 2016 Mirantis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"log"
+
+	extensionsv1beta1 "k8s.io/client-go/kubernetes/typed/extensions/v1beta1"
+	"k8s.io/client-go/pkg/apis/extensions/v1beta1"
+
+	"github.com/Mirantis/k8s-AppController/pkg/client"
+	"github.com/Mirantis/k8s-AppController/pkg/interfaces"
+	"github.com/Mirantis/k8s-AppController/pkg/report"
+)
+
+type Ingress struct {
+	Base
+	Ingress   *v1beta1.Ingress
+	Client    extensionsv1beta1.IngressInterface
+	APIClient client.Interface
+}
+
+// ingressBackends returns every Service name an Ingress routes to: the
+// default backend (if set) plus every rule's per-path backends.
+func ingressBackends(ingress *v1beta1.Ingress) []string {
+	names := make([]string, 0)
+	if ingress.Spec.Backend != nil {
+		names = append(names, ingress.Spec.Backend.ServiceName)
+	}
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			names = append(names, path.Backend.ServiceName)
+		}
+	}
+	return names
+}
+
+func ingressStatus(c extensionsv1beta1.IngressInterface, name string, apiClient client.Interface) (string, error) {
+	ingress, err := c.Get(name)
+	if err != nil {
+		return "error", err
+	}
+
+	for _, tls := range ingress.Spec.TLS {
+		if tls.SecretName == "" {
+			continue
+		}
+		if _, err := apiClient.Secrets().Get(tls.SecretName); err != nil {
+			return "error", fmt.Errorf("tls secret %s referenced by ingress %s is not available: %v", tls.SecretName, name, err)
+		}
+	}
+
+	for _, serviceName := range ingressBackends(ingress) {
+		status, err := serviceStatus(apiClient.Services(), serviceName, apiClient)
+		if status != "ready" || err != nil {
+			return status, err
+		}
+	}
+
+	if len(ingress.Status.LoadBalancer.Ingress) == 0 {
+		return "not ready", nil
+	}
+	for _, lbIngress := range ingress.Status.LoadBalancer.Ingress {
+		if lbIngress.IP == "" && lbIngress.Hostname == "" {
+			return "not ready", nil
+		}
+	}
+
+	return "ready", nil
+}
+
+func ingressKey(name string) string {
+	return "ingress/" + name
+}
+
+func (i Ingress) Key() string {
+	return ingressKey(i.Ingress.Name)
+}
+
+func (i Ingress) Create() error {
+	if err := checkExistence(i); err != nil {
+		log.Println("Creating ", i.Key())
+		i.Ingress, err = i.Client.Create(i.Ingress)
+		return err
+	}
+	return nil
+}
+
+// Delete deletes Ingress from the cluster
+func (i Ingress) Delete() error {
+	return i.Client.Delete(i.Ingress.Name, nil)
+}
+
+func (i Ingress) Status(meta map[string]string) (string, error) {
+	return ingressStatus(i.Client, i.Ingress.Name, i.APIClient)
+}
+
+// NameMatches gets resource definition and a name and checks if
+// the Ingress part of resource definition has matching name.
+func (i Ingress) NameMatches(def client.ResourceDefinition, name string) bool {
+	return def.Ingress != nil && def.Ingress.Name == name
+}
+
+// New returns new Ingress based on resource definition
+func (i Ingress) New(def client.ResourceDefinition, c client.Interface) interfaces.Resource {
+	return NewIngress(def.Ingress, c.Ingresses(), c, def.Meta)
+}
+
+// NewExisting returns new ExistingIngress based on resource definition
+func (i Ingress) NewExisting(name string, c client.Interface) interfaces.Resource {
+	return NewExistingIngress(name, c.Ingresses(), c)
+}
+
+// NewIngress is Ingress constructor. Needs apiClient for backend status checks
+func NewIngress(ingress *v1beta1.Ingress, client extensionsv1beta1.IngressInterface, apiClient client.Interface, meta map[string]interface{}) interfaces.Resource {
+	return report.SimpleReporter{BaseResource: Ingress{Base: Base{meta}, Ingress: ingress, Client: client, APIClient: apiClient}}
+}
+
+type ExistingIngress struct {
+	Base
+	Name      string
+	Client    extensionsv1beta1.IngressInterface
+	APIClient client.Interface
+}
+
+func (i ExistingIngress) Key() string {
+	return ingressKey(i.Name)
+}
+
+func (i ExistingIngress) Create() error {
+	return createExistingResource(i)
+}
+
+func (i ExistingIngress) Status(meta map[string]string) (string, error) {
+	return ingressStatus(i.Client, i.Name, i.APIClient)
+}
+
+// Delete deletes Ingress from the cluster
+func (i ExistingIngress) Delete() error {
+	return i.Client.Delete(i.Name, nil)
+}
+
+func NewExistingIngress(name string, client extensionsv1beta1.IngressInterface, apiClient client.Interface) interfaces.Resource {
+	return report.SimpleReporter{BaseResource: ExistingIngress{Name: name, Client: client, APIClient: apiClient}}
+}
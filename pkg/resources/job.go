@@ -0,0 +1,133 @@
+// Copyright 2016 Mirantis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"log"
+
+	batchv1client "k8s.io/client-go/kubernetes/typed/batch/v1"
+	batchv1 "k8s.io/client-go/pkg/apis/batch/v1"
+	"k8s.io/client-go/pkg/runtime"
+	"k8s.io/client-go/pkg/types"
+
+	"github.com/Mirantis/k8s-AppController/pkg/client"
+	"github.com/Mirantis/k8s-AppController/pkg/interfaces"
+	"github.com/Mirantis/k8s-AppController/pkg/report"
+)
+
+type Job struct {
+	Base
+	Job    *batchv1.Job
+	Client batchv1client.JobInterface
+}
+
+func jobStatus(j *batchv1.Job) (string, error) {
+	if j.Status.Succeeded > 0 {
+		return "ready", nil
+	}
+	if j.Status.Failed > 0 {
+		return "error", nil
+	}
+	return "not ready", nil
+}
+
+func jobKey(name string) string {
+	return "job/" + name
+}
+
+func (j Job) Key() string {
+	return jobKey(j.Job.Name)
+}
+
+func (j Job) Create() error {
+	if err := checkExistence(j); err != nil {
+		log.Println("Creating ", j.Key())
+		j.Job, err = j.Client.Create(j.Job)
+		return err
+	}
+	return applyChanges(
+		j.Job,
+		func() (runtime.Object, error) { return j.Client.Get(j.Job.Name) },
+		func(patchType types.PatchType, patch []byte) (runtime.Object, error) {
+			return j.Client.Patch(j.Job.Name, patchType, patch)
+		},
+		j.Meta,
+	)
+}
+
+// Delete deletes Job from the cluster
+func (j Job) Delete() error {
+	return j.Client.Delete(j.Job.Name, nil)
+}
+
+func (j Job) Status(meta map[string]string) (string, error) {
+	job, err := j.Client.Get(j.Job.Name)
+	if err != nil {
+		return "error", err
+	}
+	return jobStatus(job)
+}
+
+// NameMatches gets resource definition and a name and checks if
+// the Job part of resource definition has matching name.
+func (j Job) NameMatches(def client.ResourceDefinition, name string) bool {
+	return def.Job != nil && def.Job.Name == name
+}
+
+// New returns new Job based on resource definition
+func (j Job) New(def client.ResourceDefinition, c client.Interface) interfaces.Resource {
+	return NewJob(def.Job, c.Jobs(), def.Meta)
+}
+
+// NewExisting returns new ExistingJob based on resource definition
+func (j Job) NewExisting(name string, c client.Interface) interfaces.Resource {
+	return NewExistingJob(name, c.Jobs())
+}
+
+// NewJob is Job constructor
+func NewJob(job *batchv1.Job, client batchv1client.JobInterface, meta map[string]interface{}) interfaces.Resource {
+	return report.SimpleReporter{BaseResource: Job{Base: Base{meta}, Job: job, Client: client}}
+}
+
+type ExistingJob struct {
+	Base
+	Name   string
+	Client batchv1client.JobInterface
+}
+
+func (j ExistingJob) Key() string {
+	return jobKey(j.Name)
+}
+
+func (j ExistingJob) Create() error {
+	return createExistingResource(j)
+}
+
+func (j ExistingJob) Status(meta map[string]string) (string, error) {
+	job, err := j.Client.Get(j.Name)
+	if err != nil {
+		return "error", err
+	}
+	return jobStatus(job)
+}
+
+// Delete deletes Job from the cluster
+func (j ExistingJob) Delete() error {
+	return j.Client.Delete(j.Name, nil)
+}
+
+func NewExistingJob(name string, client batchv1client.JobInterface) interfaces.Resource {
+	return report.SimpleReporter{BaseResource: ExistingJob{Name: name, Client: client}}
+}
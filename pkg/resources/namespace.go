@@ -0,0 +1,301 @@
+// Copyright 2016 Mirantis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/pkg/api/errors"
+	"k8s.io/client-go/pkg/api/v1"
+
+	"github.com/Mirantis/k8s-AppController/pkg/client"
+	"github.com/Mirantis/k8s-AppController/pkg/interfaces"
+	"github.com/Mirantis/k8s-AppController/pkg/report"
+)
+
+// dependentsMeta is the resource-definition meta key through which the graph
+// builder lists other resource keys ("service/foo", "job/bar", ...) in the
+// current dependency graph that target this Namespace. Delete uses it to
+// refuse cascading past resources it doesn't manage the lifecycle of.
+const dependentsMeta = "dependents"
+
+// namespaceDeleteWaitInitialBackoff is the starting delay between polls for
+// a namespace to leave the Terminating phase.
+const namespaceDeleteWaitInitialBackoff = time.Second
+
+// namespaceDeleteWaitMaxBackoff caps the backoff between Terminating polls.
+const namespaceDeleteWaitMaxBackoff = 30 * time.Second
+
+// namespaceDeleteWaitDefaultDeadline is used when meta["timeout"] is not set.
+const namespaceDeleteWaitDefaultDeadline = 5 * time.Minute
+
+type Namespace struct {
+	Base
+	Namespace *v1.Namespace
+	Client    corev1.NamespaceInterface
+	APIClient client.Interface
+}
+
+func namespaceKey(name string) string {
+	return "namespace/" + name
+}
+
+func (n Namespace) Key() string {
+	return namespaceKey(n.Namespace.Name)
+}
+
+func (n Namespace) Create() error {
+	if err := checkExistence(n); err != nil {
+		log.Println("Creating ", n.Key())
+		n.Namespace, err = n.Client.Create(n.Namespace)
+		return err
+	}
+	return nil
+}
+
+// dependents returns the resource keys the graph builder recorded under
+// dependentsMeta as targeting this Namespace at graph-build time. The value
+// comes in as []interface{} (it was decoded from the resource definition's
+// YAML/JSON meta block, not assembled in Go), so a direct []string assertion
+// never matches; non-string entries are skipped rather than failing outright.
+func dependents(meta map[string]interface{}) []string {
+	raw, ok := meta[dependentsMeta].([]interface{})
+	if !ok {
+		return nil
+	}
+	keys := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if key, ok := v.(string); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// stillExist filters keys (as recorded by dependents) down to the ones whose
+// resource is still present in the cluster, so a namespace whose dependents
+// have already been cleaned up isn't blocked from deletion forever.
+func stillExist(apiClient client.Interface, keys []string) ([]string, error) {
+	living := make([]string, 0, len(keys))
+	for _, key := range keys {
+		kind, name, ok := splitResourceKey(key)
+		if !ok {
+			continue
+		}
+		exists, err := resourceExists(apiClient, kind, name)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			living = append(living, key)
+		}
+	}
+	return living, nil
+}
+
+// splitResourceKey splits a "kind/name" resource key as produced by the
+// various *Key functions in this package (e.g. serviceKey, jobKey).
+func splitResourceKey(key string) (kind, name string, ok bool) {
+	i := strings.Index(key, "/")
+	if i < 0 {
+		return "", "", false
+	}
+	return key[:i], key[i+1:], true
+}
+
+// resourceExists reports whether the named object of the given kind is still
+// present in the cluster. Kinds this package doesn't know how to cascade
+// through are treated as gone, so an unrecognized key never blocks deletion.
+func resourceExists(apiClient client.Interface, kind, name string) (bool, error) {
+	var err error
+	switch kind {
+	case "service":
+		_, err = apiClient.Services().Get(name)
+	case "ingress":
+		_, err = apiClient.Ingresses().Get(name)
+	case "job":
+		_, err = apiClient.Jobs().Get(name)
+	case "replicaset":
+		_, err = apiClient.ReplicaSets().Get(name)
+	case "deployment":
+		_, err = apiClient.Deployments().Get(name)
+	case "statefulset":
+		_, err = apiClient.StatefulSets().Get(name)
+	case "namespace":
+		_, err = apiClient.Namespaces().Get(name)
+	default:
+		return false, nil
+	}
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Delete removes the Namespace from the cluster. It refuses to run while any
+// of the resources the graph builder recorded as targeting this namespace
+// (meta["dependents"]) still exist, since deleting the namespace out from
+// under them would orphan their objects outside AppController's bookkeeping.
+// When meta["wait"] is "true" it blocks with exponential backoff until the
+// namespace is gone from the cluster (see waitForNamespaceGone).
+func (n Namespace) Delete() error {
+	if deps := dependents(n.Base.Meta); len(deps) > 0 {
+		living, err := stillExist(n.APIClient, deps)
+		if err != nil {
+			return err
+		}
+		if len(living) > 0 {
+			return fmt.Errorf("namespace %s is still targeted by %v, refusing to delete", n.Namespace.Name, living)
+		}
+	}
+
+	if err := n.Client.Delete(n.Namespace.Name, nil); err != nil {
+		return err
+	}
+
+	if wait, _ := n.Base.Meta["wait"].(string); wait != "true" {
+		return nil
+	}
+	return waitForNamespaceGone(n.Client, n.Namespace.Name, n.Base.Meta)
+}
+
+// waitForNamespaceGone polls with exponential backoff until name is gone
+// (Get returns IsNotFound), or the deadline derived from meta["timeout"]
+// elapses. It does not key on Status.Phase: the namespace controller flips a
+// namespace to Terminating asynchronously, so the first Get right after
+// Delete commonly still observes Active, and returning as soon as the phase
+// merely isn't Terminating would report teardown complete while it's still
+// running.
+func waitForNamespaceGone(c corev1.NamespaceInterface, name string, meta map[string]interface{}) error {
+	deadline := namespaceDeleteWaitDefaultDeadline
+	if timeout, ok := meta["timeout"].(string); ok {
+		if parsed, err := time.ParseDuration(timeout); err == nil {
+			deadline = parsed
+		}
+	}
+
+	start := time.Now()
+	backoff := namespaceDeleteWaitInitialBackoff
+	for {
+		_, err := c.Get(name)
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if time.Since(start) >= deadline {
+			return fmt.Errorf("namespace %s did not terminate within %s", name, deadline)
+		}
+
+		time.Sleep(backoff)
+		if backoff < namespaceDeleteWaitMaxBackoff {
+			backoff *= 2
+			if backoff > namespaceDeleteWaitMaxBackoff {
+				backoff = namespaceDeleteWaitMaxBackoff
+			}
+		}
+	}
+}
+
+func (n Namespace) Status(meta map[string]string) (string, error) {
+	namespace, err := n.Client.Get(n.Namespace.Name)
+	if err != nil {
+		return "error", err
+	}
+	if namespace.Status.Phase != v1.NamespaceActive {
+		return "not ready", nil
+	}
+	return "ready", nil
+}
+
+// NameMatches gets resource definition and a name and checks if
+// the Namespace part of resource definition has matching name.
+func (n Namespace) NameMatches(def client.ResourceDefinition, name string) bool {
+	return def.Namespace != nil && def.Namespace.Name == name
+}
+
+// New returns new Namespace based on resource definition
+func (n Namespace) New(def client.ResourceDefinition, c client.Interface) interfaces.Resource {
+	return NewNamespace(def.Namespace, c.Namespaces(), c, def.Meta)
+}
+
+// NewExisting returns new ExistingNamespace based on resource definition
+func (n Namespace) NewExisting(name string, c client.Interface) interfaces.Resource {
+	return NewExistingNamespace(name, c.Namespaces(), c)
+}
+
+// NewNamespace is Namespace constructor
+func NewNamespace(namespace *v1.Namespace, client corev1.NamespaceInterface, apiClient client.Interface, meta map[string]interface{}) interfaces.Resource {
+	return report.SimpleReporter{BaseResource: Namespace{Base: Base{meta}, Namespace: namespace, Client: client, APIClient: apiClient}}
+}
+
+type ExistingNamespace struct {
+	Base
+	Name      string
+	Client    corev1.NamespaceInterface
+	APIClient client.Interface
+}
+
+func (n ExistingNamespace) Key() string {
+	return namespaceKey(n.Name)
+}
+
+func (n ExistingNamespace) Create() error {
+	return createExistingResource(n)
+}
+
+func (n ExistingNamespace) Status(meta map[string]string) (string, error) {
+	namespace, err := n.Client.Get(n.Name)
+	if err != nil {
+		return "error", err
+	}
+	if namespace.Status.Phase != v1.NamespaceActive {
+		return "not ready", nil
+	}
+	return "ready", nil
+}
+
+// Delete deletes Namespace from the cluster
+func (n ExistingNamespace) Delete() error {
+	if deps := dependents(n.Base.Meta); len(deps) > 0 {
+		living, err := stillExist(n.APIClient, deps)
+		if err != nil {
+			return err
+		}
+		if len(living) > 0 {
+			return fmt.Errorf("namespace %s is still targeted by %v, refusing to delete", n.Name, living)
+		}
+	}
+	if err := n.Client.Delete(n.Name, nil); err != nil {
+		return err
+	}
+	if wait, _ := n.Base.Meta["wait"].(string); wait != "true" {
+		return nil
+	}
+	return waitForNamespaceGone(n.Client, n.Name, n.Base.Meta)
+}
+
+func NewExistingNamespace(name string, client corev1.NamespaceInterface, apiClient client.Interface) interfaces.Resource {
+	return report.SimpleReporter{BaseResource: ExistingNamespace{Name: name, Client: client, APIClient: apiClient}}
+}
@@ -0,0 +1,34 @@
+// Copyright 2016 Mirantis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"github.com/Mirantis/k8s-AppController/pkg/interfaces"
+)
+
+// Kinds lists the zero-value prototype of every resources.Kind the graph
+// builder knows how to decode a client.ResourceDefinition into. Only
+// NameMatches/New/NewExisting are ever called on these zero values - Key,
+// Create, Delete and Status are only meaningful once New/NewExisting has
+// produced a real instance.
+var Kinds = []interfaces.BaseResource{
+	Service{},
+	Ingress{},
+	Namespace{},
+	Job{},
+	ReplicaSet{},
+	Deployment{},
+	StatefulSet{},
+}
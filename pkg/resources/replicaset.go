@@ -0,0 +1,130 @@
+// Copyright 2016 Mirantis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"log"
+
+	extensionsv1beta1client "k8s.io/client-go/kubernetes/typed/extensions/v1beta1"
+	extensionsv1beta1 "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+	"k8s.io/client-go/pkg/runtime"
+	"k8s.io/client-go/pkg/types"
+
+	"github.com/Mirantis/k8s-AppController/pkg/client"
+	"github.com/Mirantis/k8s-AppController/pkg/interfaces"
+	"github.com/Mirantis/k8s-AppController/pkg/report"
+)
+
+type ReplicaSet struct {
+	Base
+	ReplicaSet *extensionsv1beta1.ReplicaSet
+	Client     extensionsv1beta1client.ReplicaSetInterface
+}
+
+func replicaSetStatus(rs *extensionsv1beta1.ReplicaSet) (string, error) {
+	if rs.Status.ReadyReplicas >= *rs.Spec.Replicas {
+		return "ready", nil
+	}
+	return "not ready", nil
+}
+
+func replicaSetKey(name string) string {
+	return "replicaset/" + name
+}
+
+func (r ReplicaSet) Key() string {
+	return replicaSetKey(r.ReplicaSet.Name)
+}
+
+func (r ReplicaSet) Create() error {
+	if err := checkExistence(r); err != nil {
+		log.Println("Creating ", r.Key())
+		r.ReplicaSet, err = r.Client.Create(r.ReplicaSet)
+		return err
+	}
+	return applyChanges(
+		r.ReplicaSet,
+		func() (runtime.Object, error) { return r.Client.Get(r.ReplicaSet.Name) },
+		func(patchType types.PatchType, patch []byte) (runtime.Object, error) {
+			return r.Client.Patch(r.ReplicaSet.Name, patchType, patch)
+		},
+		r.Meta,
+	)
+}
+
+// Delete deletes ReplicaSet from the cluster
+func (r ReplicaSet) Delete() error {
+	return r.Client.Delete(r.ReplicaSet.Name, nil)
+}
+
+func (r ReplicaSet) Status(meta map[string]string) (string, error) {
+	replicaSet, err := r.Client.Get(r.ReplicaSet.Name)
+	if err != nil {
+		return "error", err
+	}
+	return replicaSetStatus(replicaSet)
+}
+
+// NameMatches gets resource definition and a name and checks if
+// the ReplicaSet part of resource definition has matching name.
+func (r ReplicaSet) NameMatches(def client.ResourceDefinition, name string) bool {
+	return def.ReplicaSet != nil && def.ReplicaSet.Name == name
+}
+
+// New returns new ReplicaSet based on resource definition
+func (r ReplicaSet) New(def client.ResourceDefinition, c client.Interface) interfaces.Resource {
+	return NewReplicaSet(def.ReplicaSet, c.ReplicaSets(), def.Meta)
+}
+
+// NewExisting returns new ExistingReplicaSet based on resource definition
+func (r ReplicaSet) NewExisting(name string, c client.Interface) interfaces.Resource {
+	return NewExistingReplicaSet(name, c.ReplicaSets())
+}
+
+// NewReplicaSet is ReplicaSet constructor
+func NewReplicaSet(replicaSet *extensionsv1beta1.ReplicaSet, client extensionsv1beta1client.ReplicaSetInterface, meta map[string]interface{}) interfaces.Resource {
+	return report.SimpleReporter{BaseResource: ReplicaSet{Base: Base{meta}, ReplicaSet: replicaSet, Client: client}}
+}
+
+type ExistingReplicaSet struct {
+	Base
+	Name   string
+	Client extensionsv1beta1client.ReplicaSetInterface
+}
+
+func (r ExistingReplicaSet) Key() string {
+	return replicaSetKey(r.Name)
+}
+
+func (r ExistingReplicaSet) Create() error {
+	return createExistingResource(r)
+}
+
+func (r ExistingReplicaSet) Status(meta map[string]string) (string, error) {
+	replicaSet, err := r.Client.Get(r.Name)
+	if err != nil {
+		return "error", err
+	}
+	return replicaSetStatus(replicaSet)
+}
+
+// Delete deletes ReplicaSet from the cluster
+func (r ExistingReplicaSet) Delete() error {
+	return r.Client.Delete(r.Name, nil)
+}
+
+func NewExistingReplicaSet(name string, client extensionsv1beta1client.ReplicaSetInterface) interfaces.Resource {
+	return report.SimpleReporter{BaseResource: ExistingReplicaSet{Name: name, Client: client}}
+}
@@ -17,18 +17,39 @@ package resources
 import (
 	"fmt"
 	"log"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
 
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/pkg/api"
 	"k8s.io/client-go/pkg/api/v1"
 	"k8s.io/client-go/pkg/apis/apps/v1beta1"
 	"k8s.io/client-go/pkg/labels"
+	"k8s.io/client-go/pkg/runtime"
+	"k8s.io/client-go/pkg/runtime/schema"
+	"k8s.io/client-go/pkg/types"
+	"k8s.io/client-go/pkg/util/validation"
 
 	"github.com/Mirantis/k8s-AppController/pkg/client"
 	"github.com/Mirantis/k8s-AppController/pkg/interfaces"
 	"github.com/Mirantis/k8s-AppController/pkg/report"
 )
 
+// serviceEndpointsWaitInitialBackoff is the starting delay between endpoint polls.
+const serviceEndpointsWaitInitialBackoff = time.Second
+
+// serviceEndpointsWaitMaxBackoff caps the exponential backoff between polls.
+const serviceEndpointsWaitMaxBackoff = 30 * time.Second
+
+// serviceEndpointsWaitDefaultDeadline is used when meta["timeout"] is not set.
+const serviceEndpointsWaitDefaultDeadline = 5 * time.Minute
+
+// statusDegraded is reported when some, but not all, endpoint slots are
+// filled after half of the wait deadline has elapsed.
+const statusDegraded = "degraded"
+
 type Service struct {
 	Base
 	Service   *v1.Service
@@ -76,7 +97,7 @@ func serviceStatus(s corev1.ServiceInterface, name string, apiClient client.Inte
 		for _, r := range replicasets.Items {
 			resources = append(resources, NewReplicaSet(&r, apiClient.ReplicaSets(), nil))
 		}
-		if apiClient.IsEnabled(v1beta1.SchemeGroupVersion) {
+		if groupVersionAvailable(apiClient, v1beta1.SchemeGroupVersion) {
 			statefulsets, err := apiClient.StatefulSets().List(options)
 			if err != nil {
 				return "error", err
@@ -102,6 +123,171 @@ func serviceStatus(s corev1.ServiceInterface, name string, apiClient client.Inte
 	return "ready", nil
 }
 
+// groupVersionAvailable reports whether gv is served by the cluster, using
+// apiClient's cached discovery client so the enumeration happens once for
+// the life of the process instead of hitting the API server for every
+// resource that needs to probe it (e.g. the StatefulSet-vs-PetSet choice
+// in serviceStatus above).
+func groupVersionAvailable(apiClient client.Interface, gv schema.GroupVersion) bool {
+	groups, err := apiClient.Discovery().ServerGroups()
+	if err != nil {
+		return false
+	}
+	for _, group := range groups.Groups {
+		if group.Name != gv.Group {
+			continue
+		}
+		for _, version := range group.Versions {
+			if version.Version == gv.Version {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// serviceEndpointsReady checks whether the Service identified by name has at
+// least one routable backend for every declared port, matched by the
+// Endpoints subset's port name the way kube-proxy itself matches them (ports
+// are unnamed, and so trivially match on the common case of a single-port
+// Service). "not ready" means no declared port has any backend at all;
+// "degraded" means some but not all of them do. ExternalName services are
+// considered ready once the external name validates as a DNS name, since
+// they have no selector-backed Pods or Endpoints. Headless services
+// (ClusterIP: None) additionally require the endpoint address count to
+// exactly match the number of ready selector Pods - a mismatch is reported
+// as degraded, except that zero addresses while some selector Pods are
+// ready is reported as "not ready".
+//
+// This only consults the core v1 Endpoints object. discovery/v1 EndpointSlice
+// postdates the client-go version this tree is vendored against by several
+// releases, so there is no fallback to add here without a vendor bump;
+// Endpoints is also what this cluster's kube-proxy itself is still consuming,
+// so it remains an accurate source of truth.
+func serviceEndpointsReady(s corev1.ServiceInterface, apiClient client.Interface, name string) (string, error) {
+	service, err := s.Get(name)
+	if err != nil {
+		return "error", err
+	}
+
+	if service.Spec.Type == v1.ServiceTypeExternalName {
+		if errs := validation.IsDNS1123Subdomain(service.Spec.ExternalName); len(errs) > 0 {
+			return "error", fmt.Errorf("service %s is of type ExternalName but externalName %q is not a valid DNS name: %s", name, service.Spec.ExternalName, strings.Join(errs, "; "))
+		}
+		return "ready", nil
+	}
+
+	endpoints, err := apiClient.Endpoints().Get(name)
+	if err != nil {
+		return "error", err
+	}
+
+	totalAddresses := 0
+	addressesByPortName := make(map[string]int, len(service.Spec.Ports))
+	for _, subset := range endpoints.Subsets {
+		totalAddresses += len(subset.Addresses)
+		if len(subset.Addresses) == 0 {
+			continue
+		}
+		for _, port := range subset.Ports {
+			addressesByPortName[port.Name] += len(subset.Addresses)
+		}
+	}
+
+	satisfiedPorts := 0
+	for _, port := range service.Spec.Ports {
+		if addressesByPortName[port.Name] > 0 {
+			satisfiedPorts++
+		}
+	}
+
+	switch {
+	case len(service.Spec.Ports) > 0 && satisfiedPorts == 0:
+		return "not ready", nil
+	case satisfiedPorts < len(service.Spec.Ports):
+		return statusDegraded, nil
+	}
+
+	if service.Spec.ClusterIP == v1.ClusterIPNone {
+		options := v1.ListOptions{LabelSelector: labels.Set(service.Spec.Selector).AsSelector().String()}
+		pods, err := apiClient.Pods().List(options)
+		if err != nil {
+			return "error", err
+		}
+		readyPods := 0
+		for _, pod := range pods.Items {
+			status, err := resourceListReady([]interfaces.BaseResource{NewPod(&pod, apiClient.Pods(), nil)})
+			if err != nil {
+				return "error", err
+			}
+			if status == "ready" {
+				readyPods++
+			}
+		}
+		switch {
+		case readyPods > 0 && totalAddresses == 0:
+			return "not ready", nil
+		case totalAddresses != readyPods:
+			return statusDegraded, nil
+		}
+	}
+
+	return "ready", nil
+}
+
+// waitForServiceEndpoints polls serviceEndpointsReady with exponential
+// backoff (starting at serviceEndpointsWaitInitialBackoff, capped at
+// serviceEndpointsWaitMaxBackoff) until the Service is ready, the context is
+// cancelled, or the deadline derived from meta["timeout"] elapses. Once at
+// least half of the deadline has elapsed since the first poll, a poll that
+// finds the Service partially filled (serviceEndpointsReady reports
+// "degraded") returns "degraded" at once instead of waiting out the rest of
+// the deadline, so the reporter can
+// surface partial availability instead of treating it as a hard failure. A
+// Service that never gets past zero filled ports returns "not ready" at the
+// deadline instead.
+func waitForServiceEndpoints(ctx context.Context, s corev1.ServiceInterface, apiClient client.Interface, name string, meta map[string]string) (string, error) {
+	deadline := serviceEndpointsWaitDefaultDeadline
+	if timeout, ok := meta["timeout"]; ok {
+		if parsed, err := time.ParseDuration(timeout); err == nil {
+			deadline = parsed
+		}
+	}
+
+	start := time.Now()
+	backoff := serviceEndpointsWaitInitialBackoff
+	for {
+		status, err := serviceEndpointsReady(s, apiClient, name)
+		if err != nil {
+			return "error", err
+		}
+		if status == "ready" {
+			return "ready", nil
+		}
+
+		elapsed := time.Since(start)
+		if status == statusDegraded && elapsed >= deadline/2 {
+			return statusDegraded, nil
+		}
+		if elapsed >= deadline {
+			return "not ready", nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return "not ready", ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		if backoff < serviceEndpointsWaitMaxBackoff {
+			backoff *= 2
+			if backoff > serviceEndpointsWaitMaxBackoff {
+				backoff = serviceEndpointsWaitMaxBackoff
+			}
+		}
+	}
+}
+
 func serviceKey(name string) string {
 	return "service/" + name
 }
@@ -116,7 +302,14 @@ func (s Service) Create() error {
 		s.Service, err = s.Client.Create(s.Service)
 		return err
 	}
-	return nil
+	return applyChanges(
+		s.Service,
+		func() (runtime.Object, error) { return s.Client.Get(s.Service.Name) },
+		func(patchType types.PatchType, patch []byte) (runtime.Object, error) {
+			return s.Client.Patch(s.Service.Name, patchType, patch)
+		},
+		s.Meta,
+	)
 }
 
 // Delete deletes Service from the cluster
@@ -128,6 +321,19 @@ func (s Service) Status(meta map[string]string) (string, error) {
 	return serviceStatus(s.Client, s.Service.Name, s.APIClient)
 }
 
+// Wait first checks the selector-backed resources via serviceStatus and
+// returns that result at once if they are not ready. Otherwise it blocks in
+// waitForServiceEndpoints until the Service has at least one routable
+// endpoint address per declared port, ctx is cancelled, or the
+// meta["timeout"] deadline elapses. It implements interfaces.WaitableResource.
+func (s Service) Wait(ctx context.Context, meta map[string]string) (string, error) {
+	status, err := serviceStatus(s.Client, s.Service.Name, s.APIClient)
+	if status != "ready" || err != nil {
+		return status, err
+	}
+	return waitForServiceEndpoints(ctx, s.Client, s.APIClient, s.Service.Name, meta)
+}
+
 // NameMatches gets resource definition and a name and checks if
 // the Service part of resource definition has matching name.
 func (s Service) NameMatches(def client.ResourceDefinition, name string) bool {
@@ -168,6 +374,19 @@ func (s ExistingService) Status(meta map[string]string) (string, error) {
 	return serviceStatus(s.Client, s.Name, s.APIClient)
 }
 
+// Wait first checks the selector-backed resources via serviceStatus and
+// returns that result at once if they are not ready. Otherwise it blocks in
+// waitForServiceEndpoints until the Service has at least one routable
+// endpoint address per declared port, ctx is cancelled, or the
+// meta["timeout"] deadline elapses. It implements interfaces.WaitableResource.
+func (s ExistingService) Wait(ctx context.Context, meta map[string]string) (string, error) {
+	status, err := serviceStatus(s.Client, s.Name, s.APIClient)
+	if status != "ready" || err != nil {
+		return status, err
+	}
+	return waitForServiceEndpoints(ctx, s.Client, s.APIClient, s.Name, meta)
+}
+
 // Delete deletes Service from the cluster
 func (s ExistingService) Delete() error {
 	return s.Client.Delete(s.Name, nil)
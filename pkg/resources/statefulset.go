@@ -0,0 +1,132 @@
+// Copyright 2016 Mirantis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"log"
+
+	appsv1beta1client "k8s.io/client-go/kubernetes/typed/apps/v1beta1"
+	appsv1beta1 "k8s.io/client-go/pkg/apis/apps/v1beta1"
+	"k8s.io/client-go/pkg/runtime"
+	"k8s.io/client-go/pkg/types"
+
+	"github.com/Mirantis/k8s-AppController/pkg/client"
+	"github.com/Mirantis/k8s-AppController/pkg/interfaces"
+	"github.com/Mirantis/k8s-AppController/pkg/report"
+)
+
+type StatefulSet struct {
+	Base
+	StatefulSet *appsv1beta1.StatefulSet
+	Client      appsv1beta1client.StatefulSetInterface
+	APIClient   client.Interface
+}
+
+func statefulSetStatus(ss *appsv1beta1.StatefulSet) (string, error) {
+	if ss.Status.ReadyReplicas >= *ss.Spec.Replicas {
+		return "ready", nil
+	}
+	return "not ready", nil
+}
+
+func statefulSetKey(name string) string {
+	return "statefulset/" + name
+}
+
+func (s StatefulSet) Key() string {
+	return statefulSetKey(s.StatefulSet.Name)
+}
+
+func (s StatefulSet) Create() error {
+	if err := checkExistence(s); err != nil {
+		log.Println("Creating ", s.Key())
+		s.StatefulSet, err = s.Client.Create(s.StatefulSet)
+		return err
+	}
+	return applyChanges(
+		s.StatefulSet,
+		func() (runtime.Object, error) { return s.Client.Get(s.StatefulSet.Name) },
+		func(patchType types.PatchType, patch []byte) (runtime.Object, error) {
+			return s.Client.Patch(s.StatefulSet.Name, patchType, patch)
+		},
+		s.Meta,
+	)
+}
+
+// Delete deletes StatefulSet from the cluster
+func (s StatefulSet) Delete() error {
+	return s.Client.Delete(s.StatefulSet.Name, nil)
+}
+
+func (s StatefulSet) Status(meta map[string]string) (string, error) {
+	statefulSet, err := s.Client.Get(s.StatefulSet.Name)
+	if err != nil {
+		return "error", err
+	}
+	return statefulSetStatus(statefulSet)
+}
+
+// NameMatches gets resource definition and a name and checks if
+// the StatefulSet part of resource definition has matching name.
+func (s StatefulSet) NameMatches(def client.ResourceDefinition, name string) bool {
+	return def.StatefulSet != nil && def.StatefulSet.Name == name
+}
+
+// New returns new StatefulSet based on resource definition
+func (s StatefulSet) New(def client.ResourceDefinition, c client.Interface) interfaces.Resource {
+	return NewStatefulSet(def.StatefulSet, c.StatefulSets(), c, def.Meta)
+}
+
+// NewExisting returns new ExistingStatefulSet based on resource definition
+func (s StatefulSet) NewExisting(name string, c client.Interface) interfaces.Resource {
+	return NewExistingStatefulSet(name, c.StatefulSets(), c)
+}
+
+// NewStatefulSet is StatefulSet constructor. Needs apiClient for status checks.
+func NewStatefulSet(statefulSet *appsv1beta1.StatefulSet, client appsv1beta1client.StatefulSetInterface, apiClient client.Interface, meta map[string]interface{}) interfaces.Resource {
+	return report.SimpleReporter{BaseResource: StatefulSet{Base: Base{meta}, StatefulSet: statefulSet, Client: client, APIClient: apiClient}}
+}
+
+type ExistingStatefulSet struct {
+	Base
+	Name      string
+	Client    appsv1beta1client.StatefulSetInterface
+	APIClient client.Interface
+}
+
+func (s ExistingStatefulSet) Key() string {
+	return statefulSetKey(s.Name)
+}
+
+func (s ExistingStatefulSet) Create() error {
+	return createExistingResource(s)
+}
+
+func (s ExistingStatefulSet) Status(meta map[string]string) (string, error) {
+	statefulSet, err := s.Client.Get(s.Name)
+	if err != nil {
+		return "error", err
+	}
+	return statefulSetStatus(statefulSet)
+}
+
+// Delete deletes StatefulSet from the cluster
+func (s ExistingStatefulSet) Delete() error {
+	return s.Client.Delete(s.Name, nil)
+}
+
+func NewExistingStatefulSet(name string, client appsv1beta1client.StatefulSetInterface, apiClient client.Interface) interfaces.Resource {
+	return report.SimpleReporter{BaseResource: ExistingStatefulSet{Name: name, Client: client, APIClient: apiClient}}
+}
@@ -1,31 +1,75 @@
 package main
 
 import (
-	"k8s.io/kubernetes/pkg/api"
-	"k8s.io/kubernetes/pkg/api/unversioned"
-	"k8s.io/kubernetes/pkg/client/restclient"
+	"os"
+
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/pkg/api"
+	"k8s.io/client-go/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
+// kubeconfigEnvVar is the standard environment variable clients use to
+// locate a kubeconfig file when not running in-cluster.
+const kubeconfigEnvVar = "KUBECONFIG"
+
+// buildRestConfig loads a *rest.Config from the file named by KUBECONFIG
+// when it is set; otherwise from the in-cluster config when running inside
+// a Pod, falling back to the default kubeconfig path in the home directory.
+func buildRestConfig() (*rest.Config, error) {
+	if kubeconfig := os.Getenv(kubeconfigEnvVar); kubeconfig != "" {
+		return clientcmd.BuildConfigFromFlags("", kubeconfig)
+	}
+	if config, err := rest.InClusterConfig(); err == nil {
+		return config, nil
+	}
+	return clientcmd.BuildConfigFromFlags("", clientcmd.RecommendedHomeFile)
+}
+
+// GetAppControllerClient builds the AppController TPR/CRD client, resolving
+// its target cluster the same way the rest of the tool does instead of
+// hardcoding a localhost proxy endpoint.
 func GetAppControllerClient() (*AppControllerClient, error) {
-	version := unversioned.GroupVersion{
-		Version: "v1alpha1",
+	config, err := buildRestConfig()
+	if err != nil {
+		return nil, err
 	}
 
-	config := &restclient.Config{
-		Host:    "http://localhost:8800",
-		APIPath: "/apis/appcontroller.k8s",
-		ContentConfig: restclient.ContentConfig{
-			GroupVersion:         &version,
-			NegotiatedSerializer: api.Codecs,
-		},
+	version := schema.GroupVersion{Version: "v1alpha1"}
+	config.APIPath = "/apis/appcontroller.k8s"
+	config.ContentConfig = rest.ContentConfig{
+		GroupVersion:         &version,
+		NegotiatedSerializer: api.Codecs,
 	}
+
 	client, err := New(config)
 	if err != nil {
 		return nil, err
 	}
+
+	client.discovery, err = newCachedDiscoveryClient(config)
+	if err != nil {
+		return nil, err
+	}
 	return client, nil
 }
 
+// newCachedDiscoveryClient returns a discovery client whose ServerGroups
+// response is cached for the life of the process, so every call site that
+// currently re-probes a single hardcoded GroupVersion (e.g. the
+// StatefulSet-vs-PetSet branch in serviceStatus) can instead ask "is this
+// GroupVersion available" once instead of on every reconcile. Nothing calls
+// Invalidate() on the returned client, so a GroupVersion that only becomes
+// available after this process started won't be noticed until it restarts.
+func newCachedDiscoveryClient(config *rest.Config) (discovery.CachedDiscoveryInterface, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return discovery.NewMemCacheClient(discoveryClient), nil
+}
+
 func main() {
 
 }